@@ -1,8 +1,9 @@
-package main
+package blackjack
 
 import (
 	"fmt"
 	"math/rand"
+	"time"
 )
 
 // Suit represents a card suit
@@ -15,6 +16,22 @@ const (
 	Clubs
 )
 
+// String returns the suit's name, e.g. "Spades".
+func (s Suit) String() string {
+	switch s {
+	case Spades:
+		return "Spades"
+	case Hearts:
+		return "Hearts"
+	case Diamonds:
+		return "Diamonds"
+	case Clubs:
+		return "Clubs"
+	default:
+		return "Unknown"
+	}
+}
+
 // Rank represents a card rank
 type Rank uint8
 
@@ -34,6 +51,22 @@ const (
 	King
 )
 
+// String returns the rank's name, e.g. "Ace" or "Seven".
+func (r Rank) String() string {
+	switch r {
+	case Ace:
+		return "Ace"
+	case Jack:
+		return "Jack"
+	case Queen:
+		return "Queen"
+	case King:
+		return "King"
+	default:
+		return fmt.Sprintf("%d", int(r))
+	}
+}
+
 // Card represents a playing card
 // Card does not have to be generic. Can implement a struct with Suit and Rank that has no integer value. For the concrete class to decide what value to put on them.
 type Card struct {
@@ -46,6 +79,95 @@ func (c Card) String() string {
 	return fmt.Sprintf("%s of %s", c.Rank, c.Suit)
 }
 
+// Short returns the card's canonical two-character encoding, e.g. "As",
+// "Td", "Kh". It is the inverse of ParseCard.
+func (c Card) Short() string {
+	return string([]byte{rankChar(c.Rank), suitChar(c.Suit)})
+}
+
+func rankChar(r Rank) byte {
+	switch r {
+	case Ace:
+		return 'A'
+	case Ten:
+		return 'T'
+	case Jack:
+		return 'J'
+	case Queen:
+		return 'Q'
+	case King:
+		return 'K'
+	default:
+		return byte('0' + int(r))
+	}
+}
+
+func suitChar(s Suit) byte {
+	switch s {
+	case Spades:
+		return 's'
+	case Hearts:
+		return 'h'
+	case Diamonds:
+		return 'd'
+	case Clubs:
+		return 'c'
+	default:
+		return '?'
+	}
+}
+
+// ParseCard parses a canonical two-character card encoding (e.g. "As",
+// "Td", "Kh") as produced by Card.Short.
+func ParseCard(s string) (Card, error) {
+	if len(s) != 2 {
+		return Card{}, fmt.Errorf("card: invalid encoding %q", s)
+	}
+	rank, err := parseRank(s[0])
+	if err != nil {
+		return Card{}, err
+	}
+	suit, err := parseSuit(s[1])
+	if err != nil {
+		return Card{}, err
+	}
+	return Card{Suit: suit, Rank: rank}, nil
+}
+
+func parseRank(b byte) (Rank, error) {
+	switch {
+	case b == 'A':
+		return Ace, nil
+	case b == 'T':
+		return Ten, nil
+	case b == 'J':
+		return Jack, nil
+	case b == 'Q':
+		return Queen, nil
+	case b == 'K':
+		return King, nil
+	case b >= '2' && b <= '9':
+		return Rank(b - '0'), nil
+	default:
+		return 0, fmt.Errorf("card: invalid rank %q", b)
+	}
+}
+
+func parseSuit(b byte) (Suit, error) {
+	switch b {
+	case 's':
+		return Spades, nil
+	case 'h':
+		return Hearts, nil
+	case 'd':
+		return Diamonds, nil
+	case 'c':
+		return Clubs, nil
+	default:
+		return 0, fmt.Errorf("card: invalid suit %q", b)
+	}
+}
+
 // Deck represents a deck of cards
 // Also can be struct, assume all poker games will need a deck
 type Deck struct {
@@ -63,27 +185,6 @@ func NewDeck() *Deck {
 	return deck
 }
 
-// Shuffle shuffles the deck using the Fisher-Yates shuffle algorithm
-func (d *Deck) Shuffle() {
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	n := len(d.cards)
-	for i := n - 1; i > 0; i-- {
-		j := rng.Intn(i + 1)
-		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
-	}
-}
-
-// DrawCard draws a card from the deck
-// Assumes 
-func (d *Deck) DrawCard() Card {
-	if len(d.cards) == 0 {
-		panic("Deck is empty")
-	}
-	card := d.cards[0]
-	d.cards = d.cards[1:]
-	return card
-}
-
 // Hand represents a collection of cards
 // Generic - logic in how game is played with cards and decks should be contained within concrete class of hands
 type Hand interface {
@@ -135,72 +236,63 @@ func (h *BlackjackHand) Value() int {
 	return value
 }
 
-// PlayBlackjack simulates a Blackjack game against the dealer
-func PlayBlackjack(deck *Deck) {
-	deck := NewDeck()
-	deck.Shuffle() // Shuffle the deck before dealing cards
-	
-	player := BlackjackHand{}
-	dealer := BlackjackHand{}
+// cliAction prompts stdin for a decision on one of the player's hands. It
+// is the actionFn passed to Dealer.PlayRound when running the interactive
+// CLI.
+func cliAction(p *Player, handIdx int) Action {
+	hand := p.Round.Hands[handIdx]
+	fmt.Println("Your cards:", hand.Cards())
+	for {
+		action := ""
+		fmt.Println("Hit (h), Stand (s), Double (d), Split (p) or Surrender (r)?")
+		fmt.Scanf("%s", &action)
+
+		switch action {
+		case "h":
+			return Hit
+		case "s":
+			return Stand
+		case "d":
+			return Double
+		case "p":
+			return Split
+		case "r":
+			return Surrender
+		default:
+			fmt.Println("Invalid action. Please enter h, s, d, p or r.")
+		}
+	}
+}
+
+// PlayBlackjack runs a single interactive round of Blackjack against the
+// dealer over stdin/stdout. It seats one player on a Dealer configured
+// for the standard Blackjack GameType, places a flat bet, and drives the
+// round through PlayRound and Settle.
+func PlayBlackjack() {
+	dealer := NewDealer(Blackjack, 6, 0.75, rand.NewSource(time.Now().UnixNano()))
 
-	// Deal initial cards
-	player.AddCard(deck.DrawCard())
-	player.AddCard(deck.DrawCard())
-	dealer.AddCard(deck.DrawCard())
-	dealer.AddCard(deck.DrawCard())
+	player := &Player{Name: "You", Bankroll: 100}
+	dealer.Seat(player)
 
-	fmt.Println("Your cards:", player.Cards())
-	fmt.Println("Dealer shows:", dealer.Cards()[1]) // Don't reveal dealer's hole card
+	const flatBet = 10
+	if err := player.PlaceBet(flatBet); err != nil {
+		fmt.Println(err)
+		return
+	}
 
-	// Player turn - assuming one player, one dealer scenario
-	for {
-  	action := ""
-  	fmt.Println("Hit (h) or Stand (s)?")
-  	fmt.Scanf("%s", &action)
-  
-  	if action == "h" {
-  		player.AddCard(deck.DrawCard())
-  		fmt.Println("Your cards:", player.Cards())
-  		if player.Value() > 21 {
-  			fmt.Println("Bust!")
-  			break
-  		}
-  	} else if action == "s" {
-  		break
-  	} else {
-  		fmt.Println("Invalid action. Please enter h or s.")
-  	}
-  }
-  
-  // Dealer turn
-  fmt.Println("Dealer's cards:")
-  for _, card := range dealer.Cards() {
-  	fmt.Println(card)
-  }
-  
-  for dealer.Value() < 17 {
-  	dealer.AddCard(deck.DrawCard())
-  	fmt.Println("Dealer hits:", dealer.Cards()[len(dealer.Cards())-1])
-  	if dealer.Value() > 21 {
-  		fmt.Println("Dealer busts!")
-  		break
-  	}
-  }
-  
-  // Determine winner
-  if player.Value() > 21 || (dealer.Value() <= 21 && dealer.Value() > player.Value()) {
-  	fmt.Println("Dealer wins!")
-  } else if dealer.Value() > 21 || (player.Value() <= 21 && player.Value() > dealer.Value()) {
-  	fmt.Println("Player wins!")
-  } else {
-  	fmt.Println("Push!")
-  }
-}
-
-// Improvements:
-// Multiple players
-// Doubling down
-// Splitting pairs
-// Insurance
-// Different bet amounts
-// More complex dealer strategy
+	dealer.PlayRound(cliAction)
+
+	fmt.Println("Dealer's cards:", dealer.Hand.Cards())
+
+	for i, outcome := range dealer.Settle(player) {
+		switch outcome.Result {
+		case PlayerWins:
+			fmt.Printf("Hand %d wins! (+%d chips)\n", i+1, outcome.Delta)
+		case DealerWins:
+			fmt.Printf("Hand %d loses. (%d chips)\n", i+1, outcome.Delta)
+		case Push:
+			fmt.Printf("Hand %d pushes.\n", i+1)
+		}
+	}
+	fmt.Println("Bankroll:", player.Bankroll)
+}
@@ -0,0 +1,9 @@
+// Command blackjack runs a single interactive round of Blackjack against
+// the dealer over stdin/stdout.
+package main
+
+import blackjack "dev-amos/go-blackjack"
+
+func main() {
+	blackjack.PlayBlackjack()
+}
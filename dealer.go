@@ -0,0 +1,342 @@
+package blackjack
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// RoundState identifies where a round currently sits in the Dealer's
+// state machine.
+type RoundState uint8
+
+const (
+	Betting RoundState = iota
+	Dealing
+	InsuranceState
+	PlayerActions
+	DealerPlay
+	Settle
+	RoundOver
+)
+
+// Outcome is the result of comparing a hand's value against the dealer's.
+type Outcome uint8
+
+const (
+	PlayerWins Outcome = iota
+	DealerWins
+	Push
+)
+
+// Dealer owns the deck, seats Players, and drives a round through its
+// state machine: Betting -> Dealing -> Insurance -> PlayerActions ->
+// DealerPlay -> Settle. Custom GameTypes plug in via RegisterGameType
+// without requiring changes here.
+type Dealer struct {
+	Game    GameConfig
+	Shoe    *Shoe
+	Hand    BlackjackHand // dealer's own hand
+	Players []*Player
+	State   RoundState
+	History []HistoryEntry // actions taken this round, in order, for save/replay
+}
+
+// NewDealer creates a Dealer configured for the given GameType, with a
+// Shoe of numDecks decks dealt down to the given penetration before a
+// reshuffle becomes due. src is the randomness source for shuffling.
+func NewDealer(game GameType, numDecks int, penetration float64, src rand.Source) *Dealer {
+	cfg, ok := gameRegistry[game]
+	if !ok {
+		panic(fmt.Sprintf("dealer: unregistered game type %s", game))
+	}
+	return &Dealer{
+		Game:  cfg,
+		Shoe:  cfg.NewShoe(numDecks, penetration, src),
+		State: Betting,
+	}
+}
+
+// Seat adds a player to the table.
+func (d *Dealer) Seat(p *Player) {
+	d.Players = append(d.Players, p)
+}
+
+// draw deals the next card from the shoe, transparently reshuffling in
+// the rare case the shoe runs out mid-round instead of crashing.
+func (d *Dealer) draw() Card {
+	card, err := d.Shoe.Draw()
+	if err != nil {
+		d.Shoe.Reshuffle()
+		card, err = d.Shoe.Draw()
+		if err != nil {
+			panic(err) // a freshly reshuffled shoe can only be empty if numDecks was 0
+		}
+	}
+	return card
+}
+
+// deal gives every seated player and the dealer two cards. A player who
+// has not placed a bet (no open Round) is dealt a zero-bet hand so
+// callers that skip betting for a seat still work.
+func (d *Dealer) deal() {
+	d.Hand = BlackjackHand{}
+	for _, p := range d.Players {
+		if len(p.Round.Hands) == 0 {
+			p.Round = Round{Hands: []*BlackjackHand{{}}, Bets: []Bet{0}, Locked: []bool{false}}
+		}
+	}
+	for i := 0; i < 2; i++ {
+		for _, p := range d.Players {
+			p.Round.Hands[0].AddCard(d.draw())
+		}
+		d.Hand.AddCard(d.draw())
+	}
+}
+
+// dealerShowsAce reports whether the dealer's upcard is an Ace, the
+// trigger for offering insurance.
+func (d *Dealer) dealerShowsAce() bool {
+	return len(d.Hand.Cards()) > 0 && d.Hand.Cards()[0].Rank == Ace
+}
+
+// peekBlackjack reports whether the dealer has a two-card 21 under a
+// Rules.DealerPeeks table. Games that peek check the hole card before
+// PlayerActions, so a revealed dealer blackjack skips player decisions
+// entirely instead of letting a double or split ride against a hand
+// that was already lost.
+func (d *Dealer) peekBlackjack() bool {
+	return d.Game.Rules.DealerPeeks && len(d.Hand.Cards()) == 2 && d.Hand.Value() == 21
+}
+
+// playDealerHand hits the dealer's hand according to the GameConfig's
+// soft-17 rule until it stands or busts.
+func (d *Dealer) playDealerHand() {
+	for d.Hand.Value() < 17 || (d.Hand.Value() == 17 && d.Hand.soft && d.Game.Rules.DealerHitsSoft17) {
+		d.Hand.AddCard(d.draw())
+	}
+}
+
+// outcome compares a single hand's value against the dealer's final hand.
+func (d *Dealer) outcome(hand *BlackjackHand) Outcome {
+	playerValue, dealerValue := hand.Value(), d.Hand.Value()
+	switch {
+	case playerValue > 21:
+		return DealerWins
+	case dealerValue > 21:
+		return PlayerWins
+	case playerValue > dealerValue:
+		return PlayerWins
+	case dealerValue > playerValue:
+		return DealerWins
+	default:
+		return Push
+	}
+}
+
+// canDouble reports whether the player may double down on the hand at idx.
+func (d *Dealer) canDouble(p *Player, idx int) bool {
+	if len(p.Round.Hands[idx].Cards()) != 2 {
+		return false
+	}
+	if len(p.Round.Hands) > 1 && !d.Game.Rules.DoubleAfterSplit {
+		return false
+	}
+	return int(p.Round.Bets[idx]) <= p.Bankroll
+}
+
+// canSplit reports whether the hand at idx is a splittable pair under the
+// table's Rules.
+func (d *Dealer) canSplit(p *Player, idx int) bool {
+	hand := p.Round.Hands[idx]
+	if len(hand.Cards()) != 2 || hand.Cards()[0].Rank != hand.Cards()[1].Rank {
+		return false
+	}
+	if d.Game.Rules.MaxSplits <= 0 || len(p.Round.Hands) > d.Game.Rules.MaxSplits {
+		return false
+	}
+	return int(p.Round.Bets[idx]) <= p.Bankroll
+}
+
+// canSurrender reports whether the player may surrender the hand at idx:
+// only the original two-card hand, and only before it has been split.
+func (d *Dealer) canSurrender(p *Player, idx int) bool {
+	return idx == 0 && len(p.Round.Hands) == 1 && len(p.Round.Hands[0].Cards()) == 2
+}
+
+// legal reports whether action is one of this GameType's configured
+// LegalActions, e.g. Pontoon excludes Surrender and Insurance.
+func (d *Dealer) legal(action Action) bool {
+	for _, a := range d.Game.LegalActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// split turns the pair at idx into two one-card hands, matches the
+// original bet on the new hand, and deals each its next card. Split aces
+// are locked to their single card when the table's Rules require it.
+func (d *Dealer) split(p *Player, idx int) {
+	hand := p.Round.Hands[idx]
+	first := hand.Cards()[0]
+	wasAces := first.Rank == Ace
+	second := hand.Cards()[1]
+	bet := p.Round.Bets[idx]
+
+	*hand = BlackjackHand{}
+	hand.AddCard(first)
+	newHand := &BlackjackHand{}
+	newHand.AddCard(second)
+	p.Bankroll -= int(bet)
+
+	at := idx + 1
+	p.Round.Hands = append(p.Round.Hands, nil)
+	copy(p.Round.Hands[at+1:], p.Round.Hands[at:])
+	p.Round.Hands[at] = newHand
+
+	p.Round.Bets = append(p.Round.Bets, 0)
+	copy(p.Round.Bets[at+1:], p.Round.Bets[at:])
+	p.Round.Bets[at] = bet
+
+	p.Round.Locked = append(p.Round.Locked, false)
+	copy(p.Round.Locked[at+1:], p.Round.Locked[at:])
+	p.Round.Locked[at] = false
+
+	hand.AddCard(d.draw())
+	newHand.AddCard(d.draw())
+
+	if wasAces && d.Game.Rules.SplitAcesOnce {
+		p.Round.Locked[idx] = true
+		p.Round.Locked[at] = true
+	}
+}
+
+// playHand runs the action loop for a single hand, calling actionFn for
+// each decision until the hand stands, busts, doubles, surrenders, or
+// becomes locked (e.g. a split ace that may only take one card). Every
+// action taken is appended to d.History for later MarshalState/Replay.
+func (d *Dealer) playHand(playerIdx int, p *Player, idx int, actionFn func(p *Player, handIdx int) Action) {
+	for {
+		hand := p.Round.Hands[idx]
+		if p.Round.Locked[idx] || hand.Value() >= 21 {
+			return
+		}
+		action := actionFn(p, idx)
+		d.History = append(d.History, HistoryEntry{Player: playerIdx, Hand: idx, Action: action})
+		if !d.legal(action) {
+			return // an action outside this GameType's LegalActions can't progress the hand; stand instead
+		}
+		switch action {
+		case Hit:
+			hand.AddCard(d.draw())
+		case Double:
+			if d.canDouble(p, idx) {
+				p.Bankroll -= int(p.Round.Bets[idx])
+				p.Round.Bets[idx] *= 2
+				hand.AddCard(d.draw())
+			}
+			return
+		case Split:
+			if !d.canSplit(p, idx) {
+				return // an illegal split can't progress the hand; stand instead
+			}
+			d.split(p, idx)
+		case Surrender:
+			if d.canSurrender(p, idx) {
+				p.Round.Surrendered = true
+			}
+			return
+		default: // Stand, or Insurance, which only applies before PlayerActions
+			return
+		}
+	}
+}
+
+// Settle resolves a player's Round against the dealer's final hand,
+// crediting their bankroll and returning the outcome of every hand so the
+// engine can be driven programmatically, not just via stdin.
+func (d *Dealer) Settle(p *Player) []HandOutcome {
+	dealerBlackjack := len(d.Hand.Cards()) == 2 && d.Hand.Value() == 21
+
+	if p.Round.Insurance > 0 && dealerBlackjack {
+		p.Bankroll += int(p.Round.Insurance) * 3 // 2:1 payout, plus the insurance stake back
+	}
+
+	if p.Round.Surrendered {
+		refund := int(p.Round.Bets[0]) / 2
+		p.Bankroll += refund
+		return []HandOutcome{{Hand: p.Round.Hands[0], Result: DealerWins, Delta: refund - int(p.Round.Bets[0])}}
+	}
+
+	playerBlackjack := len(p.Round.Hands) == 1 &&
+		len(p.Round.Hands[0].Cards()) == 2 && p.Round.Hands[0].Value() == 21
+
+	outcomes := make([]HandOutcome, len(p.Round.Hands))
+	for i, hand := range p.Round.Hands {
+		bet := int(p.Round.Bets[i])
+		result := d.outcome(hand)
+
+		var delta int
+		switch {
+		case result == PlayerWins && playerBlackjack && !dealerBlackjack:
+			delta = int(float64(bet) * d.Game.Rules.BlackjackPayout)
+		case result == PlayerWins:
+			delta = bet
+		case result == Push:
+			delta = 0
+		default:
+			delta = -bet
+		}
+
+		p.Bankroll += bet + delta
+		outcomes[i] = HandOutcome{Hand: hand, Result: result, Delta: delta}
+	}
+	return outcomes
+}
+
+// PlayRound drives one full round through every state in the machine.
+// actionFn is called once per decision point to pick the Action for a
+// player's hand at handIdx (always 0 until a split creates more hands).
+func (d *Dealer) PlayRound(actionFn func(p *Player, handIdx int) Action) {
+	if d.Shoe.NeedsReshuffle() {
+		d.Shoe.Reshuffle()
+	}
+	d.History = nil
+
+	d.State = Dealing
+	d.deal()
+	peeked := d.peekBlackjack()
+
+	if d.dealerShowsAce() {
+		d.State = InsuranceState
+		for playerIdx, p := range d.Players {
+			action := actionFn(p, 0)
+			d.History = append(d.History, HistoryEntry{Player: playerIdx, Hand: 0, Action: action})
+			if action == Insurance && d.legal(Insurance) {
+				amt := p.Round.Bets[0] / 2
+				if int(amt) <= p.Bankroll {
+					p.Bankroll -= int(amt)
+					p.Round.Insurance = amt
+				}
+			}
+		}
+	}
+
+	d.State = PlayerActions
+	if !peeked {
+		for playerIdx, p := range d.Players {
+			for i := 0; i < len(p.Round.Hands); i++ {
+				d.playHand(playerIdx, p, i, actionFn)
+			}
+		}
+	}
+
+	d.State = DealerPlay
+	if !peeked {
+		d.playDealerHand()
+	}
+
+	d.State = Settle
+	d.State = RoundOver
+}
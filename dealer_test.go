@@ -0,0 +1,151 @@
+package blackjack
+
+import "testing"
+
+// fixedShoe returns a Shoe that deals cards in the given order, for
+// table tests that need to control exactly what's drawn next.
+func fixedShoe(cards ...Card) *Shoe {
+	return &Shoe{cards: cards, cursor: 0, cutCard: len(cards) + 1}
+}
+
+func newTestDealer(cards ...Card) *Dealer {
+	return &Dealer{Game: gameRegistry[Blackjack], Shoe: fixedShoe(cards...), State: Betting}
+}
+
+func TestSplitAcesKeepsSoftValue(t *testing.T) {
+	// deal() alternates player/dealer draws, so this order gives the
+	// player Ace+Ace, the dealer Two+Three, then Four and Nine drawn
+	// onto the two split hands in turn.
+	d := newTestDealer(
+		Card{Rank: Ace, Suit: Spades}, Card{Rank: Two, Suit: Clubs},
+		Card{Rank: Ace, Suit: Hearts}, Card{Rank: Three, Suit: Diamonds},
+		Card{Rank: Four, Suit: Clubs}, Card{Rank: Nine, Suit: Diamonds},
+	)
+	player := &Player{Name: "P1", Bankroll: 100}
+	d.Seat(player)
+	if err := player.PlaceBet(10); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+	d.deal()
+
+	d.split(player, 0)
+
+	if len(player.Round.Hands) != 2 {
+		t.Fatalf("Hands = %d, want 2", len(player.Round.Hands))
+	}
+	for i, hand := range player.Round.Hands {
+		if !hand.soft {
+			t.Errorf("hand %d: soft = false, want true (Ace + low card)", i)
+		}
+	}
+	first := player.Round.Hands[0]
+	if got := first.Value(); got != 15 {
+		t.Errorf("hand 0 Value() = %d, want 15 (soft Ace+4)", got)
+	}
+	if !player.Round.Locked[0] || !player.Round.Locked[1] {
+		t.Error("split aces should be locked to one card under Rules.SplitAcesOnce")
+	}
+}
+
+func TestCanDouble(t *testing.T) {
+	d := newTestDealer()
+	player := &Player{Name: "P1", Bankroll: 5}
+	d.Seat(player)
+	player.Round = Round{
+		Hands: []*BlackjackHand{{cards: []Card{{Rank: Six, Suit: Spades}, {Rank: Five, Suit: Hearts}}}},
+		Bets:  []Bet{10},
+	}
+
+	if d.canDouble(player, 0) {
+		t.Error("canDouble: expected false when bet exceeds bankroll")
+	}
+
+	player.Bankroll = 20
+	if !d.canDouble(player, 0) {
+		t.Error("canDouble: expected true for a fresh two-card hand within bankroll")
+	}
+}
+
+func TestSurrenderRefundsHalfBet(t *testing.T) {
+	// player gets Ten+Six (16), dealer gets Ten+Seven (17, stands
+	// without drawing).
+	d := newTestDealer(
+		Card{Rank: Ten, Suit: Spades}, Card{Rank: Ten, Suit: Clubs},
+		Card{Rank: Six, Suit: Hearts}, Card{Rank: Seven, Suit: Diamonds},
+	)
+	player := &Player{Name: "P1", Bankroll: 100}
+	d.Seat(player)
+	if err := player.PlaceBet(20); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+
+	d.PlayRound(func(p *Player, handIdx int) Action { return Surrender })
+
+	if !player.Round.Surrendered {
+		t.Fatal("expected Round.Surrendered = true")
+	}
+	outcomes := d.Settle(player)
+	if len(outcomes) != 1 || outcomes[0].Delta != -10 {
+		t.Fatalf("Settle() outcomes = %+v, want one hand with Delta -10", outcomes)
+	}
+	if player.Bankroll != 90 {
+		t.Errorf("Bankroll = %d, want 90 (100 - 20 bet + 10 refund)", player.Bankroll)
+	}
+}
+
+func TestInsurancePaysOnDealerBlackjack(t *testing.T) {
+	// player gets Nine+Eight (17), dealer gets Ace+King (blackjack).
+	d := newTestDealer(
+		Card{Rank: Nine, Suit: Spades}, Card{Rank: Ace, Suit: Clubs},
+		Card{Rank: Eight, Suit: Hearts}, Card{Rank: King, Suit: Diamonds},
+	)
+	d.Game.Rules.DealerPeeks = false // let PlayerActions/DealerPlay run so Settle sees the final hands
+	player := &Player{Name: "P1", Bankroll: 100}
+	d.Seat(player)
+	if err := player.PlaceBet(20); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+
+	d.PlayRound(func(p *Player, handIdx int) Action {
+		if d.State == InsuranceState {
+			return Insurance
+		}
+		return Stand
+	})
+
+	if player.Round.Insurance != 10 {
+		t.Fatalf("Round.Insurance = %d, want 10 (half of the 20 bet)", player.Round.Insurance)
+	}
+
+	outcomes := d.Settle(player)
+	if len(outcomes) != 1 || outcomes[0].Result != DealerWins {
+		t.Fatalf("Settle() outcomes = %+v, want a single DealerWins", outcomes)
+	}
+	// Insurance pays out at 3x the 10-chip stake (2:1 winnings plus the
+	// stake back), and the main hand loses its 20 bet outright to the
+	// dealer's blackjack, netting back to the starting bankroll.
+	wantBankroll := 100 - 20 /* bet, lost */ - 10 /* insurance stake */ + 30 /* insurance payout, 3x stake */
+	if player.Bankroll != wantBankroll {
+		t.Errorf("Bankroll = %d, want %d", player.Bankroll, wantBankroll)
+	}
+}
+
+func TestSettleBlackjackPayout(t *testing.T) {
+	d := newTestDealer()
+	player := &Player{Name: "P1", Bankroll: 100}
+	d.Seat(player)
+	player.Round = Round{
+		Hands:  []*BlackjackHand{hand(Card{Rank: Ace, Suit: Spades}, Card{Rank: King, Suit: Hearts})},
+		Bets:   []Bet{10},
+		Locked: []bool{false},
+	}
+	d.Hand = *hand(Card{Rank: Ten, Suit: Clubs}, Card{Rank: Nine, Suit: Diamonds})
+
+	outcomes := d.Settle(player)
+	if len(outcomes) != 1 || outcomes[0].Result != PlayerWins {
+		t.Fatalf("Settle() outcomes = %+v, want a single PlayerWins", outcomes)
+	}
+	if outcomes[0].Delta != 15 {
+		t.Errorf("Delta = %d, want 15 (10 bet * 1.5 blackjack payout)", outcomes[0].Delta)
+	}
+}
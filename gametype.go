@@ -0,0 +1,129 @@
+package blackjack
+
+import "math/rand"
+
+// GameType identifies a specific table game variant served by a Dealer.
+type GameType uint8
+
+const (
+	Blackjack GameType = iota
+	Spanish21
+	Pontoon
+	TexasHoldem // stub: registered with no Rules or LegalActions, not yet playable
+	OmahaHoldem // stub: registered with no Rules or LegalActions, not yet playable
+)
+
+// String returns the human-readable name of the game type.
+func (g GameType) String() string {
+	switch g {
+	case Blackjack:
+		return "Blackjack"
+	case Spanish21:
+		return "Spanish 21"
+	case Pontoon:
+		return "Pontoon"
+	case TexasHoldem:
+		return "Texas Hold'em"
+	case OmahaHoldem:
+		return "Omaha Hold'em"
+	default:
+		return "Unknown"
+	}
+}
+
+// Action is a move a player can make on their turn.
+type Action uint8
+
+const (
+	Hit Action = iota
+	Stand
+	Double
+	Split
+	Surrender
+	Insurance
+)
+
+// GameConfig captures everything that differs between GameType variants:
+// how the shoe is built, the table Rules, and which actions players are
+// allowed to take.
+type GameConfig struct {
+	Name         GameType
+	NewShoe      func(numDecks int, penetration float64, src rand.Source) *Shoe // deck composition, e.g. Spanish21 strips the tens
+	Rules        Rules
+	LegalActions []Action
+}
+
+// gameRegistry maps each GameType to its rules. Custom variants can be
+// added at init time via RegisterGameType without editing core Dealer code.
+var gameRegistry = map[GameType]GameConfig{}
+
+// RegisterGameType installs (or overrides) the rules for a GameType.
+func RegisterGameType(cfg GameConfig) {
+	gameRegistry[cfg.Name] = cfg
+}
+
+func init() {
+	RegisterGameType(GameConfig{
+		Name:    Blackjack,
+		NewShoe: NewShoe,
+		Rules: Rules{
+			BlackjackPayout:  1.5,
+			DealerHitsSoft17: false,
+			DealerPeeks:      true,
+			MaxSplits:        3,
+			DoubleAfterSplit: true,
+			SplitAcesOnce:    true,
+		},
+		LegalActions: []Action{Hit, Stand, Double, Split, Surrender, Insurance},
+	})
+	RegisterGameType(GameConfig{
+		Name:    Spanish21,
+		NewShoe: newSpanish21Shoe,
+		Rules: Rules{
+			BlackjackPayout:  1.5,
+			DealerHitsSoft17: true,
+			DealerPeeks:      true,
+			MaxSplits:        3,
+			DoubleAfterSplit: true,
+			SplitAcesOnce:    true,
+		},
+		LegalActions: []Action{Hit, Stand, Double, Split, Surrender},
+	})
+	RegisterGameType(GameConfig{
+		Name:    Pontoon,
+		NewShoe: NewShoe,
+		Rules: Rules{
+			BlackjackPayout:  2.0,
+			DealerHitsSoft17: true,
+			DealerPeeks:      false,
+			MaxSplits:        3,
+			DoubleAfterSplit: true,
+			SplitAcesOnce:    true,
+		},
+		LegalActions: []Action{Hit, Stand, Double, Split},
+	})
+	// TexasHoldem and OmahaHoldem are registered as stubs so NewDealer
+	// doesn't panic on them, but neither has poker-specific Rules or
+	// LegalActions yet: Dealer's hand-based state machine is built for
+	// Blackjack-style play and can't run a poker hand as-is.
+	RegisterGameType(GameConfig{Name: TexasHoldem, NewShoe: NewShoe})
+	RegisterGameType(GameConfig{Name: OmahaHoldem, NewShoe: NewShoe})
+}
+
+// newSpanish21Deck builds a deck with all tens removed, per Spanish 21 rules.
+func newSpanish21Deck() *Deck {
+	deck := NewDeck()
+	filtered := deck.cards[:0]
+	for _, c := range deck.cards {
+		if c.Rank != Ten {
+			filtered = append(filtered, c)
+		}
+	}
+	deck.cards = filtered
+	return deck
+}
+
+// newSpanish21Shoe builds a Shoe from ten-stripped decks.
+func newSpanish21Shoe(numDecks int, penetration float64, src rand.Source) *Shoe {
+	return newShoe(numDecks, penetration, src, newSpanish21Deck)
+}
@@ -0,0 +1,19 @@
+package blackjack
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewDealerAcceptsEveryRegisteredGameType(t *testing.T) {
+	for _, gt := range []GameType{Blackjack, Spanish21, Pontoon, TexasHoldem, OmahaHoldem} {
+		t.Run(gt.String(), func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("NewDealer(%v, ...) panicked: %v", gt, r)
+				}
+			}()
+			NewDealer(gt, 1, 0.75, rand.NewSource(1))
+		})
+	}
+}
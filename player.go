@@ -0,0 +1,50 @@
+package blackjack
+
+import "fmt"
+
+// Bet is the amount of chips wagered on a single hand.
+type Bet int
+
+// Round tracks everything about one player's turn within a single Dealer
+// round: one hand per split (index 0 is the original hand), the bet
+// riding on each, and any surrender/insurance decisions taken.
+type Round struct {
+	Hands       []*BlackjackHand
+	Bets        []Bet
+	Locked      []bool // true once a hand may no longer be acted on (e.g. split aces)
+	Surrendered bool
+	Insurance   Bet
+}
+
+// Player is a seat at the Dealer's table with a bankroll that persists
+// across rounds.
+type Player struct {
+	Name     string
+	Bankroll int
+	Round    Round
+}
+
+// PlaceBet wagers amt from the player's bankroll and opens a fresh Round
+// with a single empty hand for the upcoming deal.
+func (p *Player) PlaceBet(amt Bet) error {
+	if amt <= 0 {
+		return fmt.Errorf("player %s: bet must be positive, got %d", p.Name, amt)
+	}
+	if int(amt) > p.Bankroll {
+		return fmt.Errorf("player %s: bet %d exceeds bankroll %d", p.Name, amt, p.Bankroll)
+	}
+	p.Bankroll -= int(amt)
+	p.Round = Round{
+		Hands:  []*BlackjackHand{{}},
+		Bets:   []Bet{amt},
+		Locked: []bool{false},
+	}
+	return nil
+}
+
+// HandOutcome is the settled result of a single hand within a Round.
+type HandOutcome struct {
+	Hand   *BlackjackHand
+	Result Outcome
+	Delta  int // net chip change, positive means the player gained chips
+}
@@ -0,0 +1,12 @@
+package blackjack
+
+// Rules bundles the table rules that vary by GameType so GameConfig and
+// Dealer can stay generic across variants.
+type Rules struct {
+	BlackjackPayout  float64 // e.g. 1.5 for 3:2, 1.2 for 6:5
+	DealerHitsSoft17 bool
+	DealerPeeks      bool // dealer checks its hole card for blackjack before PlayerActions
+	MaxSplits        int  // 0 means splitting is not allowed; 1 allows a single split, etc.
+	DoubleAfterSplit bool // DAS: doubling is allowed on hands created by a split
+	SplitAcesOnce    bool // split aces receive exactly one card and cannot be re-split
+}
@@ -0,0 +1,80 @@
+package server
+
+import "encoding/json"
+
+// MessageType identifies the kind of message framed in an Envelope.
+type MessageType string
+
+const (
+	MsgJoinTable   MessageType = "JoinTable"
+	MsgPlaceBet    MessageType = "PlaceBet"
+	MsgAct         MessageType = "Act"
+	MsgStateUpdate MessageType = "StateUpdate"
+	MsgRoundResult MessageType = "RoundResult"
+	MsgError       MessageType = "Error"
+)
+
+// Envelope is the newline-delimited JSON frame exchanged with clients. A
+// Type tag selects how Payload is decoded. Token carries the
+// reconnection token issued at JoinTable on messages the server sends; a
+// dropped connection resumes the same seat by reconnecting and sending it
+// again as JoinTable.Token.
+type Envelope struct {
+	Type    MessageType     `json:"type"`
+	Token   string          `json:"token,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// JoinTable seats a new player under Name with a starting Bankroll. A
+// dropped connection rejoins the same seat, instead of a fresh one, by
+// sending the Token it was issued in the original Joined reply.
+type JoinTable struct {
+	Name     string `json:"name"`
+	Bankroll int    `json:"bankroll"`
+	Token    string `json:"token,omitempty"`
+}
+
+// Joined acknowledges a JoinTable, carrying the reconnection Token to
+// send as JoinTable.Token when rejoining from a new connection.
+type Joined struct {
+	Token string `json:"token"`
+}
+
+// PlaceBet wagers Amount on the upcoming round.
+type PlaceBet struct {
+	Amount int `json:"amount"`
+}
+
+// Act submits a player action for the hand at HandIndex. Action is one
+// of "Hit", "Stand", "Double", "Split", "Surrender", "Insurance".
+type Act struct {
+	HandIndex int    `json:"handIndex"`
+	Action    string `json:"action"`
+}
+
+// StateUpdate reports the table's position to a seat when it's asked to
+// decide on one of its hands.
+type StateUpdate struct {
+	DealerUpCard string   `json:"dealerUpCard"`
+	HandIndex    int      `json:"handIndex"`
+	Hand         []string `json:"hand"` // canonical two-character cards
+	Bankroll     int      `json:"bankroll"`
+}
+
+// RoundResult reports the settled outcome of every one of a seat's hands
+// at the end of a round.
+type RoundResult struct {
+	Outcomes []HandResult `json:"outcomes"`
+	Bankroll int          `json:"bankroll"`
+}
+
+// HandResult is the settled result of a single hand.
+type HandResult struct {
+	Result string `json:"result"` // "PlayerWins", "DealerWins", "Push"
+	Delta  int    `json:"delta"`
+}
+
+// ErrorMessage reports a rejected message (e.g. a bet over bankroll).
+type ErrorMessage struct {
+	Reason string `json:"reason"`
+}
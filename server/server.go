@@ -0,0 +1,400 @@
+// Package server wraps a blackjack.Dealer in a long-running TCP process
+// so remote clients — human UIs or bot programs — can join a table,
+// place bets, and submit actions instead of driving the engine in
+// process via stdin. Messages are newline-delimited JSON Envelopes
+// (see messages.go), a stdlib-only stand-in for a JSON-RPC/WebSocket
+// transport.
+package server
+
+import (
+	"bufio"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	blackjack "dev-amos/go-blackjack"
+)
+
+// TurnTimeout is how long a seat has to submit an Act before the table
+// plays Stand on its behalf.
+const TurnTimeout = 30 * time.Second
+
+// BetPollInterval is how often RunTable checks whether any seat has bet.
+const BetPollInterval = 200 * time.Millisecond
+
+// seat is one connected player's table presence: its connection, its
+// reconnection token, the blackjack.Player it controls, and a channel
+// actions arrive on from handleConn.
+type seat struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	token  string
+	player *blackjack.Player
+	acts   chan Act
+}
+
+// send writes an Envelope to the seat's connection, newline-terminated.
+func (s *seat) send(env Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = s.conn.Write(data)
+	return err
+}
+
+// betRequest asks RunTable's goroutine to place a bet on behalf of a
+// seat, since Player.Round must only ever be touched from that one
+// goroutine (see Server.betReqs).
+type betRequest struct {
+	seat   *seat
+	amount blackjack.Bet
+	result chan error
+}
+
+// betReqQueueSize bounds how many PlaceBet requests can be in flight
+// waiting for RunTable to drain them (e.g. while it's mid-PlayRound)
+// before a connection goroutine blocks sending one.
+const betReqQueueSize = 64
+
+// Server runs a single table: a Dealer plus the seats currently
+// connected to it, keyed by reconnection token. pendingSeats holds
+// seats that have joined but not yet been added to dealer.Players, and
+// betReqs carries PlaceBet requests; RunTable is the only goroutine
+// that ever touches Dealer.Players or a seated Player's Round, so both
+// are admitted/applied only from there, never concurrently with
+// PlayRound's own reads and writes of the same state.
+type Server struct {
+	dealer *blackjack.Dealer
+
+	mu           sync.Mutex
+	seats        map[string]*seat
+	pendingSeats []*seat
+
+	betReqs chan betRequest
+}
+
+// NewServer creates a Server around a fresh Dealer for the given
+// GameType, shoe size, and penetration.
+func NewServer(game blackjack.GameType, numDecks int, penetration float64, src rand.Source) *Server {
+	return &Server{
+		dealer:  blackjack.NewDealer(game, numDecks, penetration, src),
+		seats:   make(map[string]*seat),
+		betReqs: make(chan betRequest, betReqQueueSize),
+	}
+}
+
+// ListenAndServe accepts connections on addr and handles each on its own
+// goroutine until the listener errors (e.g. on shutdown).
+func (s *Server) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("server: listen %s: %w", addr, err)
+	}
+	defer ln.Close()
+
+	go s.RunTable()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return fmt.Errorf("server: accept: %w", err)
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// handleConn reads newline-delimited Envelopes from conn and dispatches
+// each to the seat this connection joined (or rejoined) with its last
+// JoinTable, until the connection closes. Envelope.Token on incoming
+// messages is not read.
+func (s *Server) handleConn(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	var mine *seat
+
+	for scanner.Scan() {
+		var env Envelope
+		if err := json.Unmarshal(scanner.Bytes(), &env); err != nil {
+			s.sendError(conn, fmt.Sprintf("malformed envelope: %v", err))
+			continue
+		}
+
+		switch env.Type {
+		case MsgJoinTable:
+			var msg JoinTable
+			if err := json.Unmarshal(env.Payload, &msg); err != nil {
+				s.sendError(conn, fmt.Sprintf("malformed JoinTable: %v", err))
+				continue
+			}
+			mine = s.join(conn, msg)
+
+		case MsgPlaceBet:
+			if mine == nil {
+				s.sendError(conn, "place bet: not joined")
+				continue
+			}
+			var msg PlaceBet
+			if err := json.Unmarshal(env.Payload, &msg); err != nil {
+				s.sendError(conn, fmt.Sprintf("malformed PlaceBet: %v", err))
+				continue
+			}
+			result := make(chan error, 1)
+			s.betReqs <- betRequest{seat: mine, amount: blackjack.Bet(msg.Amount), result: result}
+			if err := <-result; err != nil {
+				s.sendError(conn, err.Error())
+			}
+
+		case MsgAct:
+			if mine == nil {
+				s.sendError(conn, "act: not joined")
+				continue
+			}
+			var msg Act
+			if err := json.Unmarshal(env.Payload, &msg); err != nil {
+				s.sendError(conn, fmt.Sprintf("malformed Act: %v", err))
+				continue
+			}
+			mine.acts <- msg
+
+		default:
+			s.sendError(conn, fmt.Sprintf("unknown message type %q", env.Type))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Printf("server: connection error: %v", err)
+	}
+}
+
+// join seats a new player, or reattaches conn to an existing seat when
+// the request carries a token already on file, so a dropped connection
+// can reconnect mid-table instead of losing its place. A newly created
+// seat is queued in pendingSeats rather than seated on the Dealer
+// immediately; RunTable admits it between rounds.
+func (s *Server) join(conn net.Conn, msg JoinTable) *seat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if msg.Token != "" {
+		if existing, ok := s.seats[msg.Token]; ok {
+			existing.mu.Lock()
+			existing.conn = conn
+			existing.mu.Unlock()
+			existing.send(Envelope{Type: MsgJoinTable, Token: existing.token, Payload: mustMarshal(Joined{Token: existing.token})})
+			return existing
+		}
+	}
+
+	token := newToken()
+	sea := &seat{
+		conn:   conn,
+		token:  token,
+		player: &blackjack.Player{Name: msg.Name, Bankroll: msg.Bankroll},
+		acts:   make(chan Act, 1),
+	}
+	s.seats[token] = sea
+	s.pendingSeats = append(s.pendingSeats, sea)
+
+	sea.send(Envelope{Type: MsgJoinTable, Token: token, Payload: mustMarshal(Joined{Token: token})})
+	return sea
+}
+
+// admitPendingSeats adds every seat queued by join since the last call
+// to the Dealer's table. It must only be called from RunTable's
+// goroutine, between rounds, so Dealer.Players is never appended to
+// while PlayRound is concurrently ranging over it.
+func (s *Server) admitPendingSeats() {
+	s.mu.Lock()
+	pending := s.pendingSeats
+	s.pendingSeats = nil
+	s.mu.Unlock()
+
+	for _, sea := range pending {
+		s.dealer.Seat(sea.player)
+	}
+}
+
+// drainBetRequests applies every PlaceBet request queued so far and
+// reports each result back over its own channel. Like
+// admitPendingSeats, it must only run from RunTable's goroutine,
+// between rounds: PlaceBet writes a Player's Round, the same state
+// PlayRound itself reads and writes while a round is in progress.
+func (s *Server) drainBetRequests() {
+	for {
+		select {
+		case req := <-s.betReqs:
+			req.result <- req.seat.player.PlaceBet(req.amount)
+		default:
+			return
+		}
+	}
+}
+
+// sendError writes an Error envelope directly to conn. handleConn uses it
+// for every rejected message, including failures that happen before a
+// seat is known (e.g. a malformed JoinTable).
+func (s *Server) sendError(conn net.Conn, reason string) {
+	data, err := json.Marshal(Envelope{Type: MsgError, Payload: mustMarshal(ErrorMessage{Reason: reason})})
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+	conn.Write(data)
+}
+
+// RunTable drives the table through rounds forever: wait for at least
+// one seated player to have an open bet, play a round prompting each seat
+// for its actions over the network, settle, and repeat.
+func (s *Server) RunTable() {
+	for {
+		if s.awaitBets() {
+			s.dealer.PlayRound(s.actionFor)
+			s.broadcastResults()
+		}
+	}
+}
+
+// awaitBets polls until at least one seated player has placed a bet,
+// admitting any newly joined seats and applying any queued PlaceBet
+// requests on every tick. Seats that haven't bet are still dealt into
+// the round, on a zero-bet hand, by Dealer.deal's fallback.
+func (s *Server) awaitBets() bool {
+	for {
+		s.admitPendingSeats()
+		s.drainBetRequests()
+
+		ready := false
+		for _, p := range s.dealer.Players {
+			if len(p.Round.Bets) > 0 && p.Round.Bets[0] > 0 {
+				ready = true
+				break
+			}
+		}
+		if ready {
+			return true
+		}
+		time.Sleep(BetPollInterval)
+	}
+}
+
+// actionFor is the Dealer's actionFn: it looks up which seat controls p,
+// sends that seat a StateUpdate, and waits for an Act or TurnTimeout.
+func (s *Server) actionFor(p *blackjack.Player, handIdx int) blackjack.Action {
+	sea := s.seatFor(p)
+	if sea == nil {
+		return blackjack.Stand
+	}
+
+	dealerUp := "??"
+	if cards := s.dealer.Hand.Cards(); len(cards) > 0 {
+		dealerUp = cards[0].Short()
+	}
+	var hand []string
+	for _, c := range p.Round.Hands[handIdx].Cards() {
+		hand = append(hand, c.Short())
+	}
+	sea.send(Envelope{Type: MsgStateUpdate, Token: sea.token, Payload: mustMarshal(StateUpdate{
+		DealerUpCard: dealerUp,
+		HandIndex:    handIdx,
+		Hand:         hand,
+		Bankroll:     p.Bankroll,
+	})})
+
+	select {
+	case act := <-sea.acts:
+		return parseAction(act.Action)
+	case <-time.After(TurnTimeout):
+		return blackjack.Stand
+	}
+}
+
+// broadcastResults settles every seated player, sends each their own
+// RoundResult, and clears their Round so the next awaitBets waits for a
+// fresh bet instead of seeing this round's stale one.
+func (s *Server) broadcastResults() {
+	for _, p := range s.dealer.Players {
+		outcomes := s.dealer.Settle(p)
+		result := RoundResult{Bankroll: p.Bankroll}
+		for _, o := range outcomes {
+			result.Outcomes = append(result.Outcomes, HandResult{Result: outcomeName(o.Result), Delta: o.Delta})
+		}
+		p.Round = blackjack.Round{}
+
+		if sea := s.seatFor(p); sea != nil {
+			sea.send(Envelope{Type: MsgRoundResult, Token: sea.token, Payload: mustMarshal(result)})
+		}
+	}
+}
+
+// seatFor finds the seat controlling p, by pointer identity.
+func (s *Server) seatFor(p *blackjack.Player) *seat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sea := range s.seats {
+		if sea.player == p {
+			return sea
+		}
+	}
+	return nil
+}
+
+// parseAction maps an Act's wire-format Action string to a
+// blackjack.Action, defaulting to Stand for anything unrecognized so a
+// malformed client can't stall a hand indefinitely.
+func parseAction(a string) blackjack.Action {
+	switch a {
+	case "Hit":
+		return blackjack.Hit
+	case "Double":
+		return blackjack.Double
+	case "Split":
+		return blackjack.Split
+	case "Surrender":
+		return blackjack.Surrender
+	case "Insurance":
+		return blackjack.Insurance
+	default:
+		return blackjack.Stand
+	}
+}
+
+// outcomeName maps a blackjack.Outcome to its wire-format string.
+func outcomeName(o blackjack.Outcome) string {
+	switch o {
+	case blackjack.PlayerWins:
+		return "PlayerWins"
+	case blackjack.DealerWins:
+		return "DealerWins"
+	default:
+		return "Push"
+	}
+}
+
+// newToken generates a reconnection token so a dropped connection can
+// resume its seat by joining again with the same token.
+func newToken() string {
+	buf := make([]byte, 16)
+	if _, err := cryptorand.Read(buf); err != nil {
+		panic(fmt.Sprintf("server: generate token: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// mustMarshal marshals v, which is always one of this package's own
+// message types and therefore never fails to encode.
+func mustMarshal(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("server: marshal %T: %v", v, err))
+	}
+	return data
+}
@@ -0,0 +1,83 @@
+package blackjack
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Shoe holds one or more decks of cards ready to be dealt. It tracks a
+// cut-card position that signals a reshuffle is due at the next round
+// boundary, and deals via an index cursor instead of reslicing.
+type Shoe struct {
+	cards     []Card
+	cursor    int
+	cutCard   int
+	numDecks  int
+	buildDeck func() *Deck
+	rng       *rand.Rand
+}
+
+// NewShoe builds a Shoe of numDecks standard 52-card decks, shuffled
+// together using src as the randomness source. penetration (0 <
+// penetration <= 1) is the fraction of the shoe dealt before the cut
+// card is reached and a reshuffle becomes due.
+func NewShoe(numDecks int, penetration float64, src rand.Source) *Shoe {
+	return newShoe(numDecks, penetration, src, NewDeck)
+}
+
+// newShoe is the shared constructor so GameConfig variants (e.g.
+// Spanish21's ten-stripped deck) can build a Shoe from their own
+// composition while still exposing the public NewShoe signature above.
+func newShoe(numDecks int, penetration float64, src rand.Source, buildDeck func() *Deck) *Shoe {
+	s := &Shoe{numDecks: numDecks, buildDeck: buildDeck, rng: rand.New(src)}
+	s.Reshuffle()
+	s.cutCard = int(float64(len(s.cards)) * penetration)
+	return s
+}
+
+// Reshuffle rebuilds the shoe from fresh copies of its deck composition
+// and shuffles it with a Fisher-Yates pass, resetting the draw cursor.
+func (s *Shoe) Reshuffle() {
+	s.cards = s.cards[:0]
+	for i := 0; i < s.numDecks; i++ {
+		s.cards = append(s.cards, s.buildDeck().cards...)
+	}
+	for i := len(s.cards) - 1; i > 0; i-- {
+		j := s.rng.Intn(i + 1)
+		s.cards[i], s.cards[j] = s.cards[j], s.cards[i]
+	}
+	s.cursor = 0
+}
+
+// NeedsReshuffle reports whether the draw cursor has passed the cut
+// card, meaning the shoe should be reshuffled before the next round.
+func (s *Shoe) NeedsReshuffle() bool {
+	return s.cursor >= s.cutCard
+}
+
+// Draw deals the next card from the shoe. It returns an error instead of
+// panicking when the shoe is exhausted, since long-running simulations
+// need to recover rather than crash.
+func (s *Shoe) Draw() (Card, error) {
+	if s.cursor >= len(s.cards) {
+		return Card{}, fmt.Errorf("shoe: no cards remain")
+	}
+	card := s.cards[s.cursor]
+	s.cursor++
+	return card, nil
+}
+
+// Burn discards the next n cards from the shoe without dealing them, as
+// is customary right after a reshuffle.
+func (s *Shoe) Burn(n int) {
+	s.cursor += n
+	if s.cursor > len(s.cards) {
+		s.cursor = len(s.cards)
+	}
+}
+
+// cardsRemaining returns how many cards are left to draw before the shoe
+// is exhausted, used to estimate decks remaining for card counting.
+func (s *Shoe) cardsRemaining() int {
+	return len(s.cards) - s.cursor
+}
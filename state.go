@@ -0,0 +1,209 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+)
+
+// HistoryEntry records one decision made during a round: which player's
+// hand it was for for, and the Action chosen.
+type HistoryEntry struct {
+	Player int
+	Hand   int
+	Action Action
+}
+
+// PlayerState is the portable form of a Player: everything needed to
+// restore their bankroll and in-progress Round.
+type PlayerState struct {
+	Name        string
+	Bankroll    int
+	Hands       [][]string // each hand's cards, canonical two-character encoding
+	Bets        []int
+	Locked      []bool
+	Surrendered bool
+	Insurance   int
+}
+
+// GameState is a complete, portable snapshot of a Dealer: the shoe's
+// remaining card order, the dealer's hand, every seat, and the action
+// history that produced the position. It round-trips through
+// MarshalState and UnmarshalState for save/replay, hand-history sharing,
+// and regression tests over historical hands. Replaying the same
+// GameState always plays out the same way. History holds the actions of
+// the Dealer's most recent PlayRound (PlayRound clears it when it
+// starts), so a state captured after bets are placed but before the next
+// PlayRound carries the previous round's actions, not those of the round
+// Replay then plays. A restored shoe past its cut card is reshuffled with
+// a fixed seed, not the original table's randomness source.
+type GameState struct {
+	Game       GameType
+	ShoeCards  []string // remaining cards, in draw order
+	ShoeCut    int
+	ShoeDecks  int // Shoe.numDecks, needed to rebuild a shoe capable of reshuffling
+	DealerHand []string
+	Players    []PlayerState
+	History    []HistoryEntry
+}
+
+// snapshot builds a GameState from the Dealer's current position.
+func (d *Dealer) snapshot() GameState {
+	state := GameState{
+		Game:      d.Game.Name,
+		ShoeCut:   d.Shoe.cutCard - d.Shoe.cursor,
+		ShoeDecks: d.Shoe.numDecks,
+		History:   d.History,
+	}
+	for _, c := range d.Shoe.cards[d.Shoe.cursor:] {
+		state.ShoeCards = append(state.ShoeCards, c.Short())
+	}
+	for _, c := range d.Hand.Cards() {
+		state.DealerHand = append(state.DealerHand, c.Short())
+	}
+	for _, p := range d.Players {
+		ps := PlayerState{
+			Name:        p.Name,
+			Bankroll:    p.Bankroll,
+			Locked:      p.Round.Locked,
+			Surrendered: p.Round.Surrendered,
+			Insurance:   int(p.Round.Insurance),
+		}
+		for _, bet := range p.Round.Bets {
+			ps.Bets = append(ps.Bets, int(bet))
+		}
+		for _, hand := range p.Round.Hands {
+			var cards []string
+			for _, c := range hand.Cards() {
+				cards = append(cards, c.Short())
+			}
+			ps.Hands = append(ps.Hands, cards)
+		}
+		state.Players = append(state.Players, ps)
+	}
+	return state
+}
+
+// MarshalState serializes the Dealer's current position to JSON.
+func MarshalState(d *Dealer) ([]byte, error) {
+	return json.Marshal(d.snapshot())
+}
+
+// UnmarshalState parses a GameState previously produced by MarshalState.
+func UnmarshalState(data []byte) (*GameState, error) {
+	var state GameState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("unmarshal game state: %w", err)
+	}
+	return &state, nil
+}
+
+// restore rebuilds a Dealer from state, without replaying any history:
+// the shoe holds exactly the recorded remaining cards in their recorded
+// order, and every seat's bankroll and Round are restored as-is.
+func (state *GameState) restore() (*Dealer, error) {
+	cfg, ok := gameRegistry[state.Game]
+	if !ok {
+		return nil, fmt.Errorf("restore game state: unregistered game type %s", state.Game)
+	}
+
+	cards, err := parseCards(state.ShoeCards)
+	if err != nil {
+		return nil, fmt.Errorf("restore game state: shoe: %w", err)
+	}
+
+	dealerHand, err := parseCards(state.DealerHand)
+	if err != nil {
+		return nil, fmt.Errorf("restore game state: dealer hand: %w", err)
+	}
+
+	dealer := &Dealer{
+		Game:    cfg,
+		Shoe:    restoreShoe(cards, state.ShoeCut, state.ShoeDecks, cfg),
+		State:   Betting,
+		History: state.History,
+	}
+	for _, c := range dealerHand {
+		dealer.Hand.AddCard(c)
+	}
+
+	for _, ps := range state.Players {
+		p := &Player{Name: ps.Name, Bankroll: ps.Bankroll}
+		p.Round = Round{
+			Locked:      ps.Locked,
+			Surrendered: ps.Surrendered,
+			Insurance:   Bet(ps.Insurance),
+		}
+		for _, bet := range ps.Bets {
+			p.Round.Bets = append(p.Round.Bets, Bet(bet))
+		}
+		for _, encoded := range ps.Hands {
+			cards, err := parseCards(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("restore game state: player %s: %w", ps.Name, err)
+			}
+			hand := &BlackjackHand{}
+			for _, c := range cards {
+				hand.AddCard(c)
+			}
+			p.Round.Hands = append(p.Round.Hands, hand)
+		}
+		dealer.Players = append(dealer.Players, p)
+	}
+
+	return dealer, nil
+}
+
+// parseCards decodes a slice of Card.Short encodings.
+func parseCards(encoded []string) ([]Card, error) {
+	cards := make([]Card, 0, len(encoded))
+	for _, s := range encoded {
+		c, err := ParseCard(s)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// restoreShoe rebuilds a Shoe from an exact card order, bypassing the
+// usual shuffle so a saved position replays deterministically. cutCard
+// is the number of cards still playable before a reshuffle is due.
+// numDecks and cfg's deck composition are also restored (not just the
+// card slice) so that if the restored shoe later needs a reshuffle,
+// Reshuffle can rebuild a full shoe instead of emptying it.
+func restoreShoe(cards []Card, cutCard, numDecks int, cfg GameConfig) *Shoe {
+	sample := cfg.NewShoe(1, 1, rand.NewSource(1))
+	return &Shoe{
+		cards:     cards,
+		cursor:    0,
+		cutCard:   cutCard,
+		numDecks:  numDecks,
+		buildDeck: sample.buildDeck,
+		rng:       rand.New(rand.NewSource(1)),
+	}
+}
+
+// Replay reconstructs a Dealer from state and drives it through one
+// round by replaying the recorded History instead of prompting a
+// Strategy or stdin, reproducing the exact hand for regression tests or
+// hand-history review.
+func Replay(state *GameState) (*Dealer, error) {
+	dealer, err := state.restore()
+	if err != nil {
+		return nil, err
+	}
+
+	steps := state.History
+	next := 0
+	dealer.PlayRound(func(p *Player, handIdx int) Action {
+		if next >= len(steps) {
+			return Stand
+		}
+		entry := steps[next]
+		next++
+		return entry.Action
+	})
+	return dealer, nil
+}
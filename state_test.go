@@ -0,0 +1,84 @@
+package blackjack
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMarshalStateRoundTrip(t *testing.T) {
+	dealer := NewDealer(Blackjack, 1, 0.75, rand.NewSource(1))
+	player := &Player{Name: "P1", Bankroll: 100}
+	dealer.Seat(player)
+
+	if err := player.PlaceBet(10); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+
+	data, err := MarshalState(dealer)
+	if err != nil {
+		t.Fatalf("MarshalState: %v", err)
+	}
+
+	state, err := UnmarshalState(data)
+	if err != nil {
+		t.Fatalf("UnmarshalState: %v", err)
+	}
+
+	if state.Game != Blackjack {
+		t.Errorf("Game = %v, want %v", state.Game, Blackjack)
+	}
+	if len(state.Players) != 1 || state.Players[0].Bankroll != 90 {
+		t.Fatalf("Players = %+v, want one player with bankroll 90", state.Players)
+	}
+}
+
+// TestReplayReshufflesWithoutPanicking reproduces a restored dealer whose
+// shoe immediately needs a reshuffle: a restored Shoe must carry its
+// numDecks and deck composition forward, not just its remaining cards,
+// or Reshuffle empties it and the next Draw panics.
+func TestReplayReshufflesWithoutPanicking(t *testing.T) {
+	dealer := NewDealer(Blackjack, 1, 0.01, rand.NewSource(1))
+	player := &Player{Name: "P1", Bankroll: 1000}
+	dealer.Seat(player)
+
+	if err := player.PlaceBet(10); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+	dealer.PlayRound(func(p *Player, handIdx int) Action { return Stand })
+
+	if err := player.PlaceBet(10); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+
+	data, err := MarshalState(dealer)
+	if err != nil {
+		t.Fatalf("MarshalState: %v", err)
+	}
+	state, err := UnmarshalState(data)
+	if err != nil {
+		t.Fatalf("UnmarshalState: %v", err)
+	}
+
+	if !state.restoreNeedsReshuffleForTest() {
+		t.Skip("shoe didn't cross the cut card on this run; reshuffle path not exercised")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Replay panicked: %v", r)
+		}
+	}()
+	if _, err := Replay(state); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+}
+
+// restoreNeedsReshuffleForTest reports whether replaying state would hit
+// a shoe already past its cut card, the scenario that used to panic.
+func (state *GameState) restoreNeedsReshuffleForTest() bool {
+	dealer, err := state.restore()
+	if err != nil {
+		return false
+	}
+	return dealer.Shoe.NeedsReshuffle()
+}
@@ -0,0 +1,347 @@
+package blackjack
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Strategy decides what Action to take for a hand, given the dealer's
+// upcard and the table's Rules. It lets PlayRound be driven without
+// stdin, for example by Simulate or a future AI/bot client.
+type Strategy interface {
+	Decide(hand *BlackjackHand, dealerUp Card, rules Rules) Action
+}
+
+// BetSizer is implemented by strategies that vary their bet size, such
+// as a card counter ramping bets with the true count. Simulate uses a
+// flat bet for strategies that don't implement it.
+type BetSizer interface {
+	Bet(bankroll int) Bet
+}
+
+// upValue returns a card's blackjack point value for strategy lookups,
+// treating any Ace as 11 (soft) since BasicStrategy branches on
+// BlackjackHand.soft separately.
+func upValue(c Card) int {
+	switch {
+	case c.Rank == Ace:
+		return 11
+	case c.Rank >= Ten:
+		return 10
+	default:
+		return int(c.Rank)
+	}
+}
+
+// BasicStrategy implements the standard blackjack basic strategy chart:
+// hit/stand/double/split driven by the player's total, the dealer's
+// upcard, and whether the hand is a pair or soft.
+type BasicStrategy struct{}
+
+// Decide looks up the chart entry for hand against dealerUp.
+func (BasicStrategy) Decide(hand *BlackjackHand, dealerUp Card, rules Rules) Action {
+	up := upValue(dealerUp)
+	cards := hand.Cards()
+
+	if len(cards) == 2 && cards[0].Rank == cards[1].Rank {
+		if action, ok := pairAction(cards[0].Rank, up); ok {
+			return action
+		}
+	}
+
+	total := hand.Value()
+	if hand.soft {
+		return softAction(total, up, rules)
+	}
+	return hardAction(total, up, rules)
+}
+
+// pairAction is the split portion of the chart. ok is false when the
+// chart has no pair entry for rank against up, so Decide falls through to
+// the hard/soft chart instead (e.g. a pair of 5s plays like a hard 10).
+// Pairs the chart never splits, like tens, return Stand with ok true.
+func pairAction(rank Rank, up int) (Action, bool) {
+	switch rank {
+	case Ace, Eight:
+		return Split, true
+	case Ten, Jack, Queen, King:
+		return Stand, true
+	case Nine:
+		if up == 7 || up >= 10 {
+			return Stand, true
+		}
+		return Split, true
+	case Seven:
+		if up <= 7 {
+			return Split, true
+		}
+	case Six:
+		if up <= 6 {
+			return Split, true
+		}
+	case Four:
+		if up == 5 || up == 6 {
+			return Split, true
+		}
+	case Three, Two:
+		if up <= 7 {
+			return Split, true
+		}
+	}
+	return Stand, false
+}
+
+// softAction is the chart for hands where an Ace is counted as 11. rules
+// shifts the doubling range: dealers that hit soft 17 play one card
+// weaker, so the chart also doubles soft 19 against a 5, not just a 6.
+func softAction(total, up int, rules Rules) Action {
+	switch total {
+	case 20, 21:
+		return Stand
+	case 19:
+		if up == 6 || (up == 5 && rules.DealerHitsSoft17) {
+			return Double
+		}
+		return Stand
+	case 18:
+		switch {
+		case up >= 9:
+			return Hit
+		case up == 2, up == 7, up == 8:
+			return Stand
+		default: // 3-6
+			return Double
+		}
+	case 17:
+		if up >= 3 && up <= 6 {
+			return Double
+		}
+		return Hit
+	case 15, 16:
+		if up >= 4 && up <= 6 {
+			return Double
+		}
+		return Hit
+	case 13, 14:
+		if up == 5 || up == 6 {
+			return Double
+		}
+		return Hit
+	default:
+		return Hit
+	}
+}
+
+// hardAction is the chart for hands with no Ace counted as 11. rules
+// shifts the total-11 double: against a dealer Ace it only holds up
+// under dealer-hits-soft-17 tables, so a stand-on-17 dealer gets a hit
+// instead.
+func hardAction(total, up int, rules Rules) Action {
+	switch {
+	case total >= 17:
+		return Stand
+	case total >= 13:
+		if up <= 6 {
+			return Stand
+		}
+		return Hit
+	case total == 12:
+		if up >= 4 && up <= 6 {
+			return Stand
+		}
+		return Hit
+	case total == 11:
+		if up == 11 && !rules.DealerHitsSoft17 {
+			return Hit
+		}
+		return Double
+	case total == 10:
+		if up <= 9 {
+			return Double
+		}
+		return Hit
+	case total == 9:
+		if up >= 3 && up <= 6 {
+			return Double
+		}
+		return Hit
+	default:
+		return Hit
+	}
+}
+
+// DealerMimic plays every hand the way the dealer plays its own: hit
+// until 17, honoring the table's soft-17 rule.
+type DealerMimic struct{}
+
+// Decide implements Strategy.
+func (DealerMimic) Decide(hand *BlackjackHand, dealerUp Card, rules Rules) Action {
+	if hand.Value() < 17 || (hand.Value() == 17 && hand.soft && rules.DealerHitsSoft17) {
+		return Hit
+	}
+	return Stand
+}
+
+// HiLoCounter implements the Hi-Lo card counting system. It keeps a
+// running count (+1 for 2-6, -1 for ten-value cards and Aces, 0
+// otherwise), derives a true count by normalizing against the decks
+// remaining in the shoe, and ramps its bet with the count. Play
+// decisions defer to BasicStrategy; count-based play deviations are
+// future work.
+type HiLoCounter struct {
+	strategy       BasicStrategy
+	runningCount   int
+	decksRemaining float64
+}
+
+// NewHiLoCounter creates a counter starting from a fresh shoe.
+func NewHiLoCounter() *HiLoCounter {
+	return &HiLoCounter{decksRemaining: 1}
+}
+
+// Observe updates the running count for a single card seen from the shoe.
+func (c *HiLoCounter) Observe(card Card) {
+	switch {
+	case card.Rank >= Two && card.Rank <= Six:
+		c.runningCount++
+	case card.Rank >= Ten:
+		c.runningCount--
+	case card.Rank == Ace:
+		c.runningCount--
+	}
+}
+
+// SetDecksRemaining updates the counter's estimate of how many decks are
+// left in the shoe, used to convert the running count to a true count.
+func (c *HiLoCounter) SetDecksRemaining(decks float64) {
+	if decks > 0 {
+		c.decksRemaining = decks
+	}
+}
+
+// TrueCount normalizes the running count by the decks remaining.
+func (c *HiLoCounter) TrueCount() float64 {
+	return float64(c.runningCount) / c.decksRemaining
+}
+
+// Bet ramps the wager with the true count: one betting unit at a
+// neutral or negative count, scaling up as the count favors the player.
+func (c *HiLoCounter) Bet(bankroll int) Bet {
+	unit := bankroll / 100
+	if unit < 1 {
+		unit = 1
+	}
+	ramp := 1
+	if tc := c.TrueCount(); tc > 1 {
+		ramp = int(tc)
+	}
+	bet := unit * ramp
+	if bet > bankroll {
+		bet = bankroll
+	}
+	return Bet(bet)
+}
+
+// Decide implements Strategy by deferring to basic strategy.
+func (c *HiLoCounter) Decide(hand *BlackjackHand, dealerUp Card, rules Rules) Action {
+	return c.strategy.Decide(hand, dealerUp, rules)
+}
+
+// Stats aggregates the outcome of a batch of simulated hands.
+type Stats struct {
+	Hands      int
+	Wins       int
+	Losses     int
+	Pushes     int
+	TotalDelta int     // sum of net chip deltas across every hand
+	EV         float64 // expected net chips per hand
+	StdDev     float64 // standard deviation of the per-hand chip delta
+}
+
+// Simulate plays hands rounds of heads-up Blackjack under rules, with
+// strat choosing every action (and, if it implements BetSizer, every bet
+// size), and returns aggregate statistics for EV/variance benchmarking.
+func Simulate(rules Rules, strat Strategy, hands int) Stats {
+	cfg := GameConfig{
+		Name:         Blackjack,
+		NewShoe:      NewShoe,
+		Rules:        rules,
+		LegalActions: []Action{Hit, Stand, Double, Split, Surrender, Insurance},
+	}
+	dealer := &Dealer{
+		Game:  cfg,
+		Shoe:  cfg.NewShoe(6, 0.75, rand.NewSource(time.Now().UnixNano())),
+		State: Betting,
+	}
+
+	player := &Player{Name: "sim", Bankroll: 1_000_000}
+	dealer.Seat(player)
+
+	deltas := make([]int, 0, hands)
+	for i := 0; i < hands; i++ {
+		bet := Bet(10)
+		if sizer, ok := strat.(BetSizer); ok {
+			bet = sizer.Bet(player.Bankroll)
+		}
+		if err := player.PlaceBet(bet); err != nil {
+			break // bankroll exhausted
+		}
+
+		dealer.PlayRound(func(p *Player, handIdx int) Action {
+			return strat.Decide(p.Round.Hands[handIdx], dealer.Hand.Cards()[0], rules)
+		})
+
+		if observer, ok := strat.(interface{ Observe(Card) }); ok {
+			for _, h := range player.Round.Hands {
+				for _, c := range h.Cards() {
+					observer.Observe(c)
+				}
+			}
+			for _, c := range dealer.Hand.Cards() {
+				observer.Observe(c)
+			}
+		}
+		if counter, ok := strat.(interface{ SetDecksRemaining(float64) }); ok {
+			counter.SetDecksRemaining(float64(dealer.Shoe.cardsRemaining()) / 52)
+		}
+
+		for _, outcome := range dealer.Settle(player) {
+			deltas = append(deltas, outcome.Delta)
+		}
+	}
+
+	return computeStats(deltas)
+}
+
+// computeStats reduces a batch of per-hand chip deltas to Stats.
+func computeStats(deltas []int) Stats {
+	stats := Stats{Hands: len(deltas)}
+	if stats.Hands == 0 {
+		return stats
+	}
+
+	sum := 0
+	for _, d := range deltas {
+		sum += d
+		switch {
+		case d > 0:
+			stats.Wins++
+		case d < 0:
+			stats.Losses++
+		default:
+			stats.Pushes++
+		}
+	}
+	stats.TotalDelta = sum
+	stats.EV = float64(sum) / float64(stats.Hands)
+
+	var variance float64
+	for _, d := range deltas {
+		diff := float64(d) - stats.EV
+		variance += diff * diff
+	}
+	stats.StdDev = math.Sqrt(variance / float64(stats.Hands))
+
+	return stats
+}
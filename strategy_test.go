@@ -0,0 +1,133 @@
+package blackjack
+
+import "testing"
+
+func hand(cards ...Card) *BlackjackHand {
+	h := &BlackjackHand{}
+	for _, c := range cards {
+		h.AddCard(c)
+	}
+	return h
+}
+
+func TestBasicStrategyDecide(t *testing.T) {
+	s17 := Rules{DealerHitsSoft17: false}
+	h17 := Rules{DealerHitsSoft17: true}
+
+	tests := []struct {
+		name     string
+		hand     *BlackjackHand
+		dealerUp Card
+		rules    Rules
+		want     Action
+	}{
+		{
+			name:     "hard 16 stands against dealer 6",
+			hand:     hand(Card{Rank: Ten, Suit: Spades}, Card{Rank: Six, Suit: Hearts}),
+			dealerUp: Card{Rank: Six, Suit: Clubs},
+			rules:    s17,
+			want:     Stand,
+		},
+		{
+			name:     "hard 16 hits against dealer 10",
+			hand:     hand(Card{Rank: Ten, Suit: Spades}, Card{Rank: Six, Suit: Hearts}),
+			dealerUp: Card{Rank: Ten, Suit: Clubs},
+			rules:    s17,
+			want:     Hit,
+		},
+		{
+			name:     "hard 11 doubles against dealer ace under H17",
+			hand:     hand(Card{Rank: Six, Suit: Spades}, Card{Rank: Five, Suit: Hearts}),
+			dealerUp: Card{Rank: Ace, Suit: Clubs},
+			rules:    h17,
+			want:     Double,
+		},
+		{
+			name:     "hard 11 hits against dealer ace under S17",
+			hand:     hand(Card{Rank: Six, Suit: Spades}, Card{Rank: Five, Suit: Hearts}),
+			dealerUp: Card{Rank: Ace, Suit: Clubs},
+			rules:    s17,
+			want:     Hit,
+		},
+		{
+			name:     "pair of eights always splits",
+			hand:     hand(Card{Rank: Eight, Suit: Spades}, Card{Rank: Eight, Suit: Hearts}),
+			dealerUp: Card{Rank: Ten, Suit: Clubs},
+			rules:    s17,
+			want:     Split,
+		},
+		{
+			name:     "soft 19 doubles against dealer 5 under H17",
+			hand:     hand(Card{Rank: Ace, Suit: Spades}, Card{Rank: Eight, Suit: Hearts}),
+			dealerUp: Card{Rank: Five, Suit: Clubs},
+			rules:    h17,
+			want:     Double,
+		},
+		{
+			name:     "soft 19 stands against dealer 5 under S17",
+			hand:     hand(Card{Rank: Ace, Suit: Spades}, Card{Rank: Eight, Suit: Hearts}),
+			dealerUp: Card{Rank: Five, Suit: Clubs},
+			rules:    s17,
+			want:     Stand,
+		},
+	}
+
+	var strat BasicStrategy
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strat.Decide(tt.hand, tt.dealerUp, tt.rules)
+			if got != tt.want {
+				t.Errorf("Decide() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulateProducesSaneStats(t *testing.T) {
+	rules := gameRegistry[Blackjack].Rules
+	stats := Simulate(rules, BasicStrategy{}, 2000)
+
+	if stats.Hands == 0 {
+		t.Fatal("Simulate: expected at least one hand played")
+	}
+	if stats.Wins+stats.Losses+stats.Pushes != stats.Hands {
+		t.Errorf("Simulate: Wins+Losses+Pushes = %d, want %d (Hands)",
+			stats.Wins+stats.Losses+stats.Pushes, stats.Hands)
+	}
+	// Basic strategy keeps the house edge small; a flat bet of 10 chips
+	// per hand should never average a full-bet loss per hand.
+	if stats.EV < -10 || stats.EV > 10 {
+		t.Errorf("Simulate: EV = %v, expected a small edge either way", stats.EV)
+	}
+}
+
+func TestHiLoCounterRampsBetWithTrueCount(t *testing.T) {
+	counter := NewHiLoCounter()
+	counter.SetDecksRemaining(2)
+
+	flat := counter.Bet(10_000)
+
+	for i := 0; i < 8; i++ {
+		counter.Observe(Card{Rank: Five, Suit: Spades})
+	}
+	ramped := counter.Bet(10_000)
+
+	if ramped <= flat {
+		t.Errorf("Bet() after a positive count = %d, want more than the flat bet %d", ramped, flat)
+	}
+}
+
+func TestDealerMimicHitsSoft17UnderH17(t *testing.T) {
+	h := hand(Card{Rank: Ace, Suit: Spades}, Card{Rank: Six, Suit: Hearts})
+	var mimic DealerMimic
+
+	got := mimic.Decide(h, Card{}, Rules{DealerHitsSoft17: true})
+	if got != Hit {
+		t.Errorf("Decide() on soft 17 under H17 = %v, want Hit", got)
+	}
+
+	got = mimic.Decide(h, Card{}, Rules{DealerHitsSoft17: false})
+	if got != Stand {
+		t.Errorf("Decide() on soft 17 under S17 = %v, want Stand", got)
+	}
+}